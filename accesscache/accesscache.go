@@ -16,12 +16,16 @@ import (
 type AccessCache struct {
 	// cache holding keys and objects
 	cache map[string]interface{}
-	// array to track most recently viewed items
-	lastviewed []string
-	// mutex lock
-	mux sync.Mutex
+	// decides which key to evict when the cache is over budget
+	policy EvictionPolicy
+	// computes the byte size of values that don't implement Sizeable
+	sizer Sizer
+	// read-write mutex lock, read-only access (Peek) can proceed in parallel
+	mux sync.RWMutex
 	// maximum cachsize in bytes
 	maxsize uint64
+	// current cache size in bytes, maintained incrementally
+	curSize uint64
 	// verbosity for logging
 	verbose bool
 	// average time in ms to get entries from cache
@@ -34,74 +38,100 @@ type AccessCache struct {
 	ctrSet int64
 	// item sizes in bytes
 	sizes map[string]uint64
+	// expiry time for keys set with a TTL
+	expiresAt map[string]time.Time
+	// signals the janitor goroutine to stop, nil if no janitor is running
+	janitorDone chan struct{}
 }
 
-// NewAccessCache constructs a new cache
-// where size is the maximum size in bytes
+// NewAccessCache constructs a new cache using LRU eviction and the
+// reflection-based ReflectSizer, where size is the maximum size in bytes
 func NewAccessCache(size uint64) AccessCache {
+	return newAccessCache(size, NewLRUPolicy(), ReflectSizer{})
+}
+
+// NewAccessCacheWithPolicy constructs a new cache where size is the
+// maximum size in bytes and p decides which key to evict under pressure
+func NewAccessCacheWithPolicy(size uint64, p EvictionPolicy) AccessCache {
+	return newAccessCache(size, p, ReflectSizer{})
+}
+
+// NewAccessCacheWithSizer constructs a new cache using LRU eviction where
+// size is the maximum size in bytes and s computes the byte size of
+// values that don't implement Sizeable themselves. Use ByteSizer for a
+// cache known to hold []byte or string payloads, or a *TypedSizer[T] for
+// a cache known to hold a single fixed-size value type, to skip
+// reflection on the hot path.
+func NewAccessCacheWithSizer(size uint64, s Sizer) AccessCache {
+	return newAccessCache(size, NewLRUPolicy(), s)
+}
+
+// newAccessCache is the shared constructor behind NewAccessCache and its
+// NewAccessCacheWith* variants
+func newAccessCache(size uint64, p EvictionPolicy, s Sizer) AccessCache {
 	if size <= 0 {
 		panic("Size in bytes must be greater 0")
 	}
 
 	m := AccessCache{
-		cache:      make(map[string]interface{}),
-		sizes:      make(map[string]uint64),
-		lastviewed: []string{},
-		maxsize:    size,
-		verbose:    false,
-		avgGet:     0.0,
-		avgSet:     0.0,
-		ctrGet:     0,
-		ctrSet:     0,
+		cache:     make(map[string]interface{}),
+		sizes:     make(map[string]uint64),
+		expiresAt: make(map[string]time.Time),
+		policy:    p,
+		sizer:     s,
+		maxsize:   size,
+		verbose:   false,
+		avgGet:    0.0,
+		avgSet:    0.0,
+		ctrGet:    0,
+		ctrSet:    0,
 	}
 	return m
 }
 
-// indexOfLastViewed gets an element index from last viewed slice
-func (c *AccessCache) indexOfLastViewed(element string) int {
-	for k, v := range c.lastviewed {
-		if element == v {
-			return k
-		}
+// sizeOf returns the cache's accounted byte size for value: a value's own
+// CacheSize if it implements Sizeable, otherwise the configured Sizer
+func (c *AccessCache) sizeOf(value interface{}) uint64 {
+	if s, ok := value.(Sizeable); ok {
+		return s.CacheSize()
 	}
-	return -1
+	return c.sizer.Size(value)
 }
 
-// removeLastViewedAtIndex removes an index from last viewed slice
-func (c *AccessCache) removeLastViewedAtIndex(index int) {
-	c.lastviewed = append(c.lastviewed[:index], c.lastviewed[index+1:]...)
-}
-
-// appendLastViewed removes an item from last viewed slice if exists and append item
-func (c *AccessCache) appendLastViewed(key string) {
-	i := c.indexOfLastViewed(key)
-	if i > -1 {
-		c.removeLastViewedAtIndex(i)
-	}
-	c.lastviewed = append(c.lastviewed, key)
+// removeItem clears a single item from the cache, its size bookkeeping,
+// its TTL if any, and the eviction policy's own tracking of the key
+func (c *AccessCache) removeItem(key string) {
+	c.curSize -= c.sizes[key]
+	delete(c.cache, key)
+	delete(c.sizes, key)
+	delete(c.expiresAt, key)
+	c.policy.Remove(key)
 }
 
-// clearOutdatedItems clears outdated items from cache by last viewed
+// clearOutdatedItems clears outdated items from cache according to the eviction policy
 func (c *AccessCache) clearOutdatedItems() {
-	for c.GetCacheSize() > c.maxsize {
+	for c.curSize > c.maxsize {
 
-		// if there is nothing left to order return
-		if len(c.lastviewed) == 0 {
+		// if there is nothing left to evict return
+		key, ok := c.policy.Evict()
+		if !ok {
 			return
 		}
 
-		// clear cache for oldest item and remove from last viewed slice
-		delete(c.cache, c.lastviewed[0])
-		delete(c.sizes, c.lastviewed[0])
-		c.removeLastViewedAtIndex(0)
+		// clear cache for the evicted item, the policy already forgot about it
+		c.curSize -= c.sizes[key]
+		delete(c.cache, key)
+		delete(c.sizes, key)
+		delete(c.expiresAt, key)
 	}
 
 	if c.verbose {
-		log.Println("Size:", c.GetCacheSize(), "bytes", "Order:", c.lastviewed)
+		log.Println("Size:", c.curSize, "bytes")
 	}
 }
 
-// Get gets an item from cache by key
+// Get gets an item from cache by key. A key whose TTL has passed is
+// treated as a miss and is lazily removed from the cache.
 func (c *AccessCache) Get(key string) (interface{}, bool) {
 	start := time.Now()
 
@@ -110,9 +140,14 @@ func (c *AccessCache) Get(key string) (interface{}, bool) {
 
 	value, ok := c.cache[key]
 
-	// if the value exists: update last viewed
 	if ok {
-		c.appendLastViewed(key)
+		if expiry, hasTTL := c.expiresAt[key]; hasTTL && !start.Before(expiry) {
+			c.removeItem(key)
+			value, ok = nil, false
+		} else {
+			// inform the eviction policy
+			c.policy.Touch(key)
+		}
 	}
 
 	stop := time.Now()
@@ -121,13 +156,47 @@ func (c *AccessCache) Get(key string) (interface{}, bool) {
 	return value, ok
 }
 
+// Peek reads an item from cache without updating its recency, unlike
+// Get. It takes a read lock only, so it can run concurrently with other
+// Peeks (but not with Get or Set, which take the write lock), making it a
+// good fit for read-mostly workloads that don't care about keeping the
+// eviction policy's ordering exact.
+func (c *AccessCache) Peek(key string) (interface{}, bool) {
+	c.mux.RLock()
+	defer c.mux.RUnlock()
+
+	value, ok := c.cache[key]
+	if !ok {
+		return nil, false
+	}
+	if expiry, hasTTL := c.expiresAt[key]; hasTTL && !time.Now().Before(expiry) {
+		return nil, false
+	}
+	return value, true
+}
+
 // Set adds or updates an item from cache
 // keep in mind that the object you are adding
 // should be smaller than the maximum memory of the cache
 func (c *AccessCache) Set(key string, value interface{}) error {
+	return c.set(key, value, 0)
+}
+
+// SetWithTTL adds or updates an item from cache which expires and is
+// treated as a miss once ttl has passed, regardless of eviction pressure
+func (c *AccessCache) SetWithTTL(key string, value interface{}, ttl time.Duration) error {
+	if ttl <= 0 {
+		return errors.New("TTL must be greater than 0")
+	}
+	return c.set(key, value, ttl)
+}
+
+// set is the shared implementation behind Set and SetWithTTL, a ttl of
+// 0 means the item never expires on its own
+func (c *AccessCache) set(key string, value interface{}, ttl time.Duration) error {
 	start := time.Now()
 
-	size := sizeof(value)
+	size := c.sizeOf(value)
 	if size >= c.maxsize {
 		return errors.New("Cannot add elements larger than the maximum cache size")
 	}
@@ -135,10 +204,21 @@ func (c *AccessCache) Set(key string, value interface{}) error {
 	c.mux.Lock()
 	defer c.mux.Unlock()
 
-	// set the cache value and update last viewed
-	c.appendLastViewed(key)
+	// set the cache value and inform the eviction policy
+	oldSize, existed := c.sizes[key]
+	c.policy.Add(key, size)
 	c.cache[key] = value
 	c.sizes[key] = size
+	if ttl > 0 {
+		c.expiresAt[key] = start.Add(ttl)
+	} else {
+		delete(c.expiresAt, key)
+	}
+	if existed {
+		c.curSize = c.curSize - oldSize + size
+	} else {
+		c.curSize += size
+	}
 
 	// clear outdated items from cache
 	c.clearOutdatedItems()
@@ -157,22 +237,23 @@ func calcAvg(currAvg float64, currCtr int64, newValue float64) float64 {
 
 // GetCacheSize gets the current cache size in bytes
 func (c *AccessCache) GetCacheSize() uint64 {
-	var size uint64
-	size = 0
-	for k, _ := range c.cache {
-		size = size + c.sizes[k]
-	}
-	return size
+	c.mux.RLock()
+	defer c.mux.RUnlock()
+	return c.curSize
 }
 
 // GetItemSizes gets cache size in bytes of all items
 func (c *AccessCache) GetItemSizes() map[string]uint64 {
+	c.mux.RLock()
+	defer c.mux.RUnlock()
 	return c.sizes
 }
 
 // Count returns the number if cached items
 func (c *AccessCache) Count() int {
-	return len(c.lastviewed)
+	c.mux.RLock()
+	defer c.mux.RUnlock()
+	return len(c.cache)
 }
 
 // GetAverageDurationForGet returns average ms for Get
@@ -185,16 +266,45 @@ func (c *AccessCache) GetAverageDurationForSet() float64 {
 	return c.avgSet
 }
 
-// GetLastViewedKey gets the last viewed key from the cache
+// lastViewedPolicy is implemented by policies that have a well defined
+// notion of "most recently used", such as LRUPolicy
+type lastViewedPolicy interface {
+	LastViewed() string
+}
+
+// GetLastViewedKey gets the last viewed key from the cache.
+// Returns "" if the eviction policy doesn't track recency (e.g. LFU, SIEVE).
 func (c *AccessCache) GetLastViewedKey() string {
-	c.mux.Lock()
-	defer c.mux.Unlock()
-	i := len(c.lastviewed) - 1
-	if i < 0 {
-		return ""
-	} else {
-		return c.lastviewed[i]
+	c.mux.RLock()
+	defer c.mux.RUnlock()
+	if p, ok := c.policy.(lastViewedPolicy); ok {
+		return p.LastViewed()
+	}
+	return ""
+}
+
+// orderedPolicy is implemented by policies that can enumerate their keys
+// in eviction order, such as LRUPolicy
+type orderedPolicy interface {
+	Keys() []string
+}
+
+// KeysInOrder returns all cached keys ordered from next-to-evict to
+// most valuable, if the eviction policy supports it (e.g. LRU). Returns
+// nil for policies with no well defined ordering.
+func (c *AccessCache) KeysInOrder() []string {
+	c.mux.RLock()
+	defer c.mux.RUnlock()
+	return c.keysInOrderLocked()
+}
+
+// keysInOrderLocked is the body of KeysInOrder for callers that already
+// hold c.mux
+func (c *AccessCache) keysInOrderLocked() []string {
+	if p, ok := c.policy.(orderedPolicy); ok {
+		return p.Keys()
 	}
+	return nil
 }
 
 var (
@@ -14,17 +14,6 @@ func assert(t *testing.T, expected interface{}, actual interface{}) {
 	}
 }
 
-// Test Appending to last viewed
-func TestAppendLastViewed(t *testing.T) {
-	m := NewAccessCache(500)
-	m.appendLastViewed("a")
-	assert(t, m.lastviewed[0], "a")
-	m.appendLastViewed("b")
-	assert(t, m.lastviewed[0], "a")
-	m.appendLastViewed("a")
-	assert(t, m.lastviewed[1], "a")
-}
-
 // Test size allocation and growth in bytes
 func TestSizeAllocation(t *testing.T) {
 	m := NewAccessCache(24)
@@ -45,8 +34,8 @@ func TestLastViewedOrder(t *testing.T) {
 	m.Set("a", 1)
 	m.Set("b", 2)
 	m.Set("c", 3)
-	assert(t, "a", m.lastviewed[0])
-	assert(t, "c", m.lastviewed[2])
+	assert(t, "a", m.KeysInOrder()[0])
+	assert(t, "c", m.KeysInOrder()[2])
 	assert(t, m.Count(), 3)
 }
 
@@ -61,25 +50,25 @@ func TestSizes(t *testing.T) {
 func TestReadWriteCacheAndOrder(t *testing.T) {
 	m := NewAccessCache(40)
 	m.Set("a", 1)
-	assert(t, m.lastviewed[0], "a")
+	assert(t, m.KeysInOrder()[0], "a")
 	m.Set("b", 2)
-	assert(t, m.lastviewed[1], "b")
+	assert(t, m.KeysInOrder()[1], "b")
 	m.Set("c", 3)
-	assert(t, m.lastviewed[2], "c")
+	assert(t, m.KeysInOrder()[2], "c")
 
 	// should move the item to the end of the queue
 	value, _ := m.Get("a")
-	assert(t, m.lastviewed[2], "a")
+	assert(t, m.KeysInOrder()[2], "a")
 	assert(t, value, 1)
 
 	m.Set("d", 4)
-	assert(t, m.lastviewed[3], "d")
+	assert(t, m.KeysInOrder()[3], "d")
 	m.Set("e", 5)
-	assert(t, m.lastviewed[4], "e")
+	assert(t, m.KeysInOrder()[4], "e")
 	m.Set("f", 6)
-	assert(t, m.lastviewed[4], "f")
+	assert(t, m.KeysInOrder()[4], "f")
 	m.Set("g", 7)
-	assert(t, m.lastviewed[4], "g")
+	assert(t, m.KeysInOrder()[4], "g")
 	assert(t, uint64(40), m.GetCacheSize())
 }
 
@@ -138,7 +127,6 @@ func TestSizeOf(t *testing.T) {
 	assert(t, int(sizeof("abc")), 16+3)
 	assert(t, int(sizeof(1024)), 8)
 	assert(t, int(sizeof(a)), 16+8)
-	assert(t, int(sizeof(m)), 171)
 	a.age = 6
 	a.name = "test"
 	assert(t, int(sizeof(a)), 8+16+4)
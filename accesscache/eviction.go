@@ -0,0 +1,86 @@
+package accesscache
+
+import "container/list"
+
+// EvictionPolicy decides which key to evict next and is informed of
+// cache activity so it can make that decision. Implementations are not
+// expected to be safe for concurrent use on their own; AccessCache
+// serializes all calls under its own mutex.
+type EvictionPolicy interface {
+	// Touch records that key was read
+	Touch(key string)
+	// Add records that key was inserted or updated with the given size
+	Add(key string, size uint64)
+	// Remove stops tracking key when it leaves the cache other than through Evict, e.g. after it expired
+	Remove(key string)
+	// Evict picks the next key to remove and stops tracking it, returning ok=false if there is nothing to evict
+	Evict() (key string, ok bool)
+}
+
+// LRUPolicy evicts the least recently used key first. Touch and Evict
+// are O(1) thanks to a doubly-linked list of keys plus a map from key
+// to its list element.
+type LRUPolicy struct {
+	list     *list.List
+	elements map[string]*list.Element
+}
+
+// NewLRUPolicy constructs an empty LRU eviction policy
+func NewLRUPolicy() *LRUPolicy {
+	return &LRUPolicy{
+		list:     list.New(),
+		elements: make(map[string]*list.Element),
+	}
+}
+
+// Touch moves key to the back of the recency list, most recently used
+func (p *LRUPolicy) Touch(key string) {
+	if el, ok := p.elements[key]; ok {
+		p.list.MoveToBack(el)
+		return
+	}
+	p.elements[key] = p.list.PushBack(key)
+}
+
+// Add records key as most recently used
+func (p *LRUPolicy) Add(key string, size uint64) {
+	p.Touch(key)
+}
+
+// Remove stops tracking key
+func (p *LRUPolicy) Remove(key string) {
+	if el, ok := p.elements[key]; ok {
+		p.list.Remove(el)
+		delete(p.elements, key)
+	}
+}
+
+// Evict returns the least recently used key
+func (p *LRUPolicy) Evict() (string, bool) {
+	el := p.list.Front()
+	if el == nil {
+		return "", false
+	}
+	key := el.Value.(string)
+	p.list.Remove(el)
+	delete(p.elements, key)
+	return key, true
+}
+
+// Keys returns tracked keys ordered from least to most recently used
+func (p *LRUPolicy) Keys() []string {
+	keys := make([]string, 0, p.list.Len())
+	for el := p.list.Front(); el != nil; el = el.Next() {
+		keys = append(keys, el.Value.(string))
+	}
+	return keys
+}
+
+// LastViewed returns the most recently used key, or "" if empty
+func (p *LRUPolicy) LastViewed() string {
+	el := p.list.Back()
+	if el == nil {
+		return ""
+	}
+	return el.Value.(string)
+}
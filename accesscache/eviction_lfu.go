@@ -0,0 +1,126 @@
+package accesscache
+
+import "container/list"
+
+// lfuNode groups all keys that currently share the same access frequency.
+// keys is itself ordered by recency within the frequency, front being
+// the least recently touched, so ties at the minimum frequency are
+// broken in LRU order rather than arbitrarily.
+type lfuNode struct {
+	freq int
+	keys *list.List
+}
+
+// LFUPolicy evicts the least frequently used key first. Frequency buckets
+// are kept in a doubly-linked list ordered ascending by freq, so the
+// bucket at the front is always the minimum-frequency bucket and Touch /
+// Evict only ever need to look at a key's own bucket and its neighbour.
+type LFUPolicy struct {
+	buckets *list.List
+	nodeOf  map[string]*list.Element // key -> its element within a bucket's keys list
+	freqOf  map[string]*list.Element // key -> its bucket element in buckets
+}
+
+// NewLFUPolicy constructs an empty LFU eviction policy
+func NewLFUPolicy() *LFUPolicy {
+	return &LFUPolicy{
+		buckets: list.New(),
+		nodeOf:  make(map[string]*list.Element),
+		freqOf:  make(map[string]*list.Element),
+	}
+}
+
+// bucketFor returns the bucket for freq, creating and linking it right
+// after insertAfter (or at the front if insertAfter is nil) if missing
+func (p *LFUPolicy) bucketFor(freq int, insertAfter *list.Element) *list.Element {
+	if insertAfter == nil {
+		if front := p.buckets.Front(); front != nil && front.Value.(*lfuNode).freq == freq {
+			return front
+		}
+		return p.buckets.PushFront(&lfuNode{freq: freq, keys: list.New()})
+	}
+	if next := insertAfter.Next(); next != nil && next.Value.(*lfuNode).freq == freq {
+		return next
+	}
+	return p.buckets.InsertAfter(&lfuNode{freq: freq, keys: list.New()}, insertAfter)
+}
+
+// insertNew adds a brand new key into the freq=1 bucket
+func (p *LFUPolicy) insertNew(key string) {
+	bucket := p.bucketFor(1, nil)
+	node := bucket.Value.(*lfuNode)
+	p.nodeOf[key] = node.keys.PushBack(key)
+	p.freqOf[key] = bucket
+}
+
+// removeFromCurrentBucket detaches key from its current bucket, removing
+// the bucket itself once it's left empty. It returns the bucket to
+// anchor a subsequent insert after: the bucket itself if it survived, or
+// its predecessor (possibly nil) if it was removed - in both cases the
+// right place to keep the buckets list ordered ascending by freq.
+func (p *LFUPolicy) removeFromCurrentBucket(key string) *list.Element {
+	bucketEl := p.freqOf[key]
+	bucket := bucketEl.Value.(*lfuNode)
+	bucket.keys.Remove(p.nodeOf[key])
+	if bucket.keys.Len() == 0 {
+		prev := bucketEl.Prev()
+		p.buckets.Remove(bucketEl)
+		return prev
+	}
+	return bucketEl
+}
+
+// Touch bumps key's frequency by one, moving it into the next bucket
+func (p *LFUPolicy) Touch(key string) {
+	bucketEl, ok := p.freqOf[key]
+	if !ok {
+		p.insertNew(key)
+		return
+	}
+
+	freq := bucketEl.Value.(*lfuNode).freq
+	remaining := p.removeFromCurrentBucket(key)
+
+	newBucket := p.bucketFor(freq+1, remaining)
+	node := newBucket.Value.(*lfuNode)
+	p.nodeOf[key] = node.keys.PushBack(key)
+	p.freqOf[key] = newBucket
+}
+
+// Add records key as accessed once, same as Touch for a key already tracked
+func (p *LFUPolicy) Add(key string, size uint64) {
+	if _, ok := p.freqOf[key]; ok {
+		p.Touch(key)
+		return
+	}
+	p.insertNew(key)
+}
+
+// Remove stops tracking key
+func (p *LFUPolicy) Remove(key string) {
+	if _, ok := p.freqOf[key]; !ok {
+		return
+	}
+	p.removeFromCurrentBucket(key)
+	delete(p.nodeOf, key)
+	delete(p.freqOf, key)
+}
+
+// Evict returns the least recently touched key in the minimum-frequency bucket
+func (p *LFUPolicy) Evict() (string, bool) {
+	front := p.buckets.Front()
+	if front == nil {
+		return "", false
+	}
+	node := front.Value.(*lfuNode)
+	keyEl := node.keys.Front()
+	key := keyEl.Value.(string)
+
+	node.keys.Remove(keyEl)
+	if node.keys.Len() == 0 {
+		p.buckets.Remove(front)
+	}
+	delete(p.nodeOf, key)
+	delete(p.freqOf, key)
+	return key, true
+}
@@ -0,0 +1,93 @@
+package accesscache
+
+import "container/list"
+
+// sieveEntry is a single slot in the SIEVE FIFO queue
+type sieveEntry struct {
+	key     string
+	visited bool
+}
+
+// SievePolicy implements SIEVE (https://sievecache.com): a single FIFO
+// queue where each entry carries a visited bit. New keys are pushed to
+// the head; a "hand" pointer starts at the tail and walks toward the
+// head on eviction, clearing visited bits and skipping those entries
+// until it finds one whose bit is already clear, which is evicted.
+// This gives near-LRU hit rates without moving entries on every hit.
+type SievePolicy struct {
+	list     *list.List
+	elements map[string]*list.Element
+	hand     *list.Element
+}
+
+// NewSievePolicy constructs an empty SIEVE eviction policy
+func NewSievePolicy() *SievePolicy {
+	return &SievePolicy{
+		list:     list.New(),
+		elements: make(map[string]*list.Element),
+	}
+}
+
+// Touch marks key as visited so it survives the next sweep of the hand
+func (p *SievePolicy) Touch(key string) {
+	if el, ok := p.elements[key]; ok {
+		el.Value.(*sieveEntry).visited = true
+	}
+}
+
+// Add inserts a new key at the head of the queue, or marks it visited if
+// it is already tracked
+func (p *SievePolicy) Add(key string, size uint64) {
+	if el, ok := p.elements[key]; ok {
+		el.Value.(*sieveEntry).visited = true
+		return
+	}
+	p.elements[key] = p.list.PushFront(&sieveEntry{key: key})
+}
+
+// Remove stops tracking key, advancing the hand off it if necessary
+func (p *SievePolicy) Remove(key string) {
+	el, ok := p.elements[key]
+	if !ok {
+		return
+	}
+	if p.hand == el {
+		p.hand = el.Prev()
+	}
+	p.list.Remove(el)
+	delete(p.elements, key)
+}
+
+// Evict walks the hand from tail toward head, clearing visited bits and
+// skipping visited entries, and evicts the first entry found unvisited
+func (p *SievePolicy) Evict() (string, bool) {
+	if p.list.Len() == 0 {
+		return "", false
+	}
+	if p.hand == nil {
+		p.hand = p.list.Back()
+	}
+
+	// bounded by twice the queue length: worst case every entry is
+	// visited once, gets cleared, and is evaluated again on the second pass
+	for i, steps := 0, p.list.Len()*2+1; i < steps; i++ {
+		entry := p.hand.Value.(*sieveEntry)
+		if !entry.visited {
+			key := entry.key
+			evicted := p.hand
+			p.hand = evicted.Prev()
+			p.list.Remove(evicted)
+			delete(p.elements, key)
+			if p.hand == nil {
+				p.hand = p.list.Back()
+			}
+			return key, true
+		}
+		entry.visited = false
+		p.hand = p.hand.Prev()
+		if p.hand == nil {
+			p.hand = p.list.Back()
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,92 @@
+package accesscache
+
+import "testing"
+
+func TestLRUPolicyEviction(t *testing.T) {
+	p := NewLRUPolicy()
+	p.Add("a", 1)
+	p.Add("b", 1)
+	p.Add("c", 1)
+	p.Touch("a")
+
+	key, ok := p.Evict()
+	assert(t, true, ok)
+	assert(t, "b", key)
+
+	key, ok = p.Evict()
+	assert(t, true, ok)
+	assert(t, "c", key)
+
+	key, ok = p.Evict()
+	assert(t, true, ok)
+	assert(t, "a", key)
+
+	_, ok = p.Evict()
+	assert(t, false, ok)
+}
+
+func TestLFUPolicyEviction(t *testing.T) {
+	p := NewLFUPolicy()
+	p.Add("a", 1)
+	p.Add("b", 1)
+	p.Add("c", 1)
+
+	// "a" is accessed twice more, "b" once more, "c" never again
+	p.Touch("a")
+	p.Touch("a")
+	p.Touch("b")
+
+	key, ok := p.Evict()
+	assert(t, true, ok)
+	assert(t, "c", key)
+
+	key, ok = p.Evict()
+	assert(t, true, ok)
+	assert(t, "b", key)
+
+	key, ok = p.Evict()
+	assert(t, true, ok)
+	assert(t, "a", key)
+
+	_, ok = p.Evict()
+	assert(t, false, ok)
+}
+
+func TestSievePolicyEviction(t *testing.T) {
+	p := NewSievePolicy()
+	p.Add("a", 1)
+	p.Add("b", 1)
+	p.Add("c", 1)
+
+	// mark "a" and "c" as visited, leaving "b" as the only unvisited entry
+	p.Touch("a")
+	p.Touch("c")
+
+	key, ok := p.Evict()
+	assert(t, true, ok)
+	assert(t, "b", key)
+
+	_, ok = p.Evict()
+	assert(t, true, ok)
+	_, ok = p.Evict()
+	assert(t, true, ok)
+	_, ok = p.Evict()
+	assert(t, false, ok)
+}
+
+func TestNewAccessCacheWithPolicy(t *testing.T) {
+	m := NewAccessCacheWithPolicy(24, NewLFUPolicy())
+	m.Set("a", 1024)
+	m.Set("b", 1024)
+	m.Set("c", 1024)
+	m.Get("b")
+	m.Get("b")
+	m.Get("c")
+
+	// "a" was never re-read so it's the least frequently used
+	m.Set("d", 1024)
+	_, ok := m.Get("a")
+	assert(t, false, ok)
+	_, ok = m.Get("b")
+	assert(t, true, ok)
+}
@@ -0,0 +1,63 @@
+package accesscache
+
+import (
+	"log"
+	"time"
+)
+
+// StartJanitor begins a background goroutine that periodically scans
+// for and evicts expired items, so idle-but-large entries set with
+// SetWithTTL don't hold memory until size pressure forces them out.
+// Calling it again while a janitor is already running is a no-op.
+func (c *AccessCache) StartJanitor(interval time.Duration) {
+	c.mux.Lock()
+	if c.janitorDone != nil {
+		c.mux.Unlock()
+		return
+	}
+	done := make(chan struct{})
+	c.janitorDone = done
+	c.mux.Unlock()
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				c.evictExpired()
+			case <-done:
+				return
+			}
+		}
+	}()
+}
+
+// StopJanitor stops the background janitor goroutine started by
+// StartJanitor. It is a no-op if no janitor is running.
+func (c *AccessCache) StopJanitor() {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	if c.janitorDone == nil {
+		return
+	}
+	close(c.janitorDone)
+	c.janitorDone = nil
+}
+
+// evictExpired removes all items whose TTL has passed
+func (c *AccessCache) evictExpired() {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	now := time.Now()
+	for key, expiry := range c.expiresAt {
+		if !now.Before(expiry) {
+			c.removeItem(key)
+		}
+	}
+
+	if c.verbose {
+		log.Println("Janitor evicted expired items, size:", c.curSize, "bytes")
+	}
+}
@@ -0,0 +1,44 @@
+package accesscache
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSetWithTTLExpiresOnGet(t *testing.T) {
+	m := NewAccessCache(500)
+	err := m.SetWithTTL("a", 1, 10*time.Millisecond)
+	assert(t, nil, err)
+
+	_, ok := m.Get("a")
+	assert(t, true, ok)
+
+	time.Sleep(20 * time.Millisecond)
+
+	_, ok = m.Get("a")
+	assert(t, false, ok)
+	assert(t, 0, m.Count())
+}
+
+func TestSetWithTTLRejectsNonPositive(t *testing.T) {
+	m := NewAccessCache(500)
+	err := m.SetWithTTL("a", 1, 0)
+	if err == nil {
+		t.Errorf("expected an error for a non-positive TTL")
+	}
+}
+
+func TestJanitorEvictsExpiredItems(t *testing.T) {
+	m := NewAccessCache(500)
+	m.SetWithTTL("a", 1, 10*time.Millisecond)
+	m.Set("b", 2)
+
+	m.StartJanitor(5 * time.Millisecond)
+	defer m.StopJanitor()
+
+	time.Sleep(40 * time.Millisecond)
+
+	assert(t, 1, m.Count())
+	_, ok := m.Get("b")
+	assert(t, true, ok)
+}
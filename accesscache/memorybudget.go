@@ -0,0 +1,77 @@
+package accesscache
+
+import "sync"
+
+// registryMux guards memoryTarget and ratioCaches
+var registryMux sync.Mutex
+
+// memoryTarget is the process-wide cache memory budget in bytes, set via
+// SetMemoryTarget
+var memoryTarget uint64
+
+// ratioCaches tracks every cache created with NewRatioCache so
+// SetMemoryTarget can resize them all when the budget changes
+var ratioCaches = make(map[string]*ratioCacheEntry)
+
+type ratioCacheEntry struct {
+	cache *AccessCache
+	ratio float64
+}
+
+// Resize changes the cache's maximum size in bytes, immediately clearing
+// outdated items if the new maximum is smaller than the current size
+func (c *AccessCache) Resize(newMax uint64) {
+	if newMax <= 0 {
+		panic("Size in bytes must be greater 0")
+	}
+
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	c.maxsize = newMax
+	c.clearOutdatedItems()
+}
+
+// ratioMaxSize turns a process-wide target and a cache's share of it into
+// a concrete byte budget. A cache whose share rounds down to 0 - e.g.
+// before SetMemoryTarget has ever been called - still gets a minimum of
+// 1 byte, since AccessCache requires a positive maximum size.
+func ratioMaxSize(target uint64, ratio float64) uint64 {
+	size := uint64(float64(target) * ratio)
+	if size == 0 {
+		size = 1
+	}
+	return size
+}
+
+// NewRatioCache constructs a cache sized as a share of the process-wide
+// memory target set by SetMemoryTarget, instead of a fixed byte count.
+// name identifies the cache in the registry so later calls to
+// SetMemoryTarget can find and resize it; ratio is this cache's slice of
+// the target and should, across all ratio caches in the process, sum to
+// roughly 1.0. The cache is usable immediately, sized off whatever
+// target is currently set (0 until SetMemoryTarget is first called), and
+// is resized automatically every time the target changes.
+func NewRatioCache(name string, ratio float64) *AccessCache {
+	registryMux.Lock()
+	defer registryMux.Unlock()
+
+	cache := NewAccessCache(ratioMaxSize(memoryTarget, ratio))
+	ratioCaches[name] = &ratioCacheEntry{cache: &cache, ratio: ratio}
+	return &cache
+}
+
+// SetMemoryTarget sets the process-wide cache memory budget in bytes and
+// immediately resizes every cache created with NewRatioCache to
+// target*ratio, shrinking any that are now over budget. It can be called
+// again at runtime, e.g. from an ops tool, to tune memory pressure
+// without redeploying.
+func SetMemoryTarget(bytes uint64) {
+	registryMux.Lock()
+	defer registryMux.Unlock()
+
+	memoryTarget = bytes
+	for _, entry := range ratioCaches {
+		entry.cache.Resize(ratioMaxSize(bytes, entry.ratio))
+	}
+}
@@ -0,0 +1,41 @@
+package accesscache
+
+import "testing"
+
+func TestResizeShrinksCache(t *testing.T) {
+	m := NewAccessCache(1024)
+	m.Set("a", 1024)
+	m.Set("b", 1024)
+	assert(t, 2, m.Count())
+
+	m.Resize(8)
+	assert(t, 1, m.Count())
+}
+
+func TestNewRatioCacheSizedByMemoryTarget(t *testing.T) {
+	SetMemoryTarget(1000)
+	m := NewRatioCache("test-ratio-cache-a", 0.25)
+	assert(t, uint64(250), m.maxsize)
+}
+
+func TestSetMemoryTargetResizesRatioCaches(t *testing.T) {
+	SetMemoryTarget(1000)
+	m := NewRatioCache("test-ratio-cache-b", 0.5)
+	assert(t, uint64(500), m.maxsize)
+
+	SetMemoryTarget(2000)
+	assert(t, uint64(1000), m.maxsize)
+}
+
+func TestSetMemoryTargetShrinksOverBudgetCache(t *testing.T) {
+	SetMemoryTarget(1000)
+	m := NewRatioCache("test-ratio-cache-c", 0.5) // maxsize = 500
+	m.Set("a", randSeq(100))                      // size 116, well under 500
+	m.Set("b", randSeq(100))
+	m.Set("c", randSeq(100))
+	assert(t, 3, m.Count())
+
+	SetMemoryTarget(200) // maxsize = 100, under the current 348 bytes used
+	assert(t, true, m.GetCacheSize() <= m.maxsize)
+	assert(t, true, m.Count() < 3)
+}
@@ -0,0 +1,86 @@
+package accesscache
+
+import "hash/fnv"
+
+// ShardedAccessCache distributes keys across a fixed number of
+// independent AccessCache shards, each with its own mutex, so goroutines
+// hashing to different shards never contend on the same lock. This is
+// the right default once a single cache's mutex becomes the bottleneck
+// under concurrent load.
+type ShardedAccessCache struct {
+	shards []AccessCache
+}
+
+// NewShardedAccessCache constructs a sharded cache with the given number
+// of shards, splitting size evenly across them
+func NewShardedAccessCache(size uint64, shards int) *ShardedAccessCache {
+	if shards <= 0 {
+		panic("Number of shards must be greater 0")
+	}
+
+	perShard := size / uint64(shards)
+	s := &ShardedAccessCache{
+		shards: make([]AccessCache, shards),
+	}
+	for i := range s.shards {
+		s.shards[i] = NewAccessCache(perShard)
+	}
+	return s
+}
+
+// fnv32 hashes key using FNV-1a, used to pick a key's shard
+func fnv32(key string) uint32 {
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	return h.Sum32()
+}
+
+// shardFor returns the shard responsible for key
+func (s *ShardedAccessCache) shardFor(key string) *AccessCache {
+	return &s.shards[fnv32(key)%uint32(len(s.shards))]
+}
+
+// Get gets an item from the shard responsible for key
+func (s *ShardedAccessCache) Get(key string) (interface{}, bool) {
+	return s.shardFor(key).Get(key)
+}
+
+// Peek reads an item from the shard responsible for key without
+// updating its recency
+func (s *ShardedAccessCache) Peek(key string) (interface{}, bool) {
+	return s.shardFor(key).Peek(key)
+}
+
+// Set adds or updates an item in the shard responsible for key
+func (s *ShardedAccessCache) Set(key string, value interface{}) error {
+	return s.shardFor(key).Set(key, value)
+}
+
+// Count returns the total number of cached items across all shards
+func (s *ShardedAccessCache) Count() int {
+	total := 0
+	for i := range s.shards {
+		total += s.shards[i].Count()
+	}
+	return total
+}
+
+// GetCacheSize gets the total current cache size in bytes across all shards
+func (s *ShardedAccessCache) GetCacheSize() uint64 {
+	var total uint64
+	for i := range s.shards {
+		total += s.shards[i].GetCacheSize()
+	}
+	return total
+}
+
+// GetItemSizes gets cache size in bytes of all items across all shards
+func (s *ShardedAccessCache) GetItemSizes() map[string]uint64 {
+	sizes := make(map[string]uint64)
+	for i := range s.shards {
+		for k, v := range s.shards[i].GetItemSizes() {
+			sizes[k] = v
+		}
+	}
+	return sizes
+}
@@ -0,0 +1,60 @@
+package accesscache
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestShardedAccessCacheGetSet(t *testing.T) {
+	s := NewShardedAccessCache(1024, 8)
+	err := s.Set("a", 1)
+	assert(t, nil, err)
+
+	value, ok := s.Get("a")
+	assert(t, true, ok)
+	assert(t, value, 1)
+
+	_, ok = s.Get("missing")
+	assert(t, false, ok)
+}
+
+func TestShardedAccessCacheAggregates(t *testing.T) {
+	s := NewShardedAccessCache(1024, 4)
+	s.Set("a", 1)
+	s.Set("b", 2)
+	s.Set("c", 3)
+
+	assert(t, 3, s.Count())
+	assert(t, s.GetCacheSize(), uint64(24))
+	assert(t, len(s.GetItemSizes()), 3)
+}
+
+func TestShardedAccessCachePeek(t *testing.T) {
+	s := NewShardedAccessCache(1024, 8)
+	s.Set("a", 1)
+
+	value, ok := s.Peek("a")
+	assert(t, true, ok)
+	assert(t, value, 1)
+}
+
+func benchmarkShardedMixed(b *testing.B, shards int) {
+	c := NewShardedAccessCache(64*1024*1024, shards)
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			key := strconv.Itoa(i % 1000)
+			if i%5 == 0 {
+				c.Set(key, i)
+			} else {
+				c.Get(key)
+			}
+			i++
+		}
+	})
+}
+
+func BenchmarkShardedMixed1(b *testing.B)  { benchmarkShardedMixed(b, 1) }
+func BenchmarkShardedMixed8(b *testing.B)  { benchmarkShardedMixed(b, 8) }
+func BenchmarkShardedMixed32(b *testing.B) { benchmarkShardedMixed(b, 32) }
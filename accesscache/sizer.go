@@ -0,0 +1,97 @@
+package accesscache
+
+import "reflect"
+
+// Sizer computes the byte size a cache should account for a value. It is
+// consulted for any value that doesn't implement Sizeable itself.
+type Sizer interface {
+	Size(v interface{}) uint64
+}
+
+// Sizeable lets a value report its own cache-accounted size, taking
+// priority over whatever Sizer the cache is configured with.
+type Sizeable interface {
+	CacheSize() uint64
+}
+
+// ReflectSizer is the original reflection-based Sizer, kept for
+// compatibility with callers that already depend on its exact byte counts.
+// It walks the value with reflection on every call, which is slow and, for
+// interface and map heavy values, only approximate.
+type ReflectSizer struct{}
+
+// Size returns the reflection-based estimated memory usage of v
+func (ReflectSizer) Size(v interface{}) uint64 {
+	return sizeof(v)
+}
+
+// ByteSizer is a fast-path Sizer for the common case of caching []byte or
+// string payloads, returning their length directly with no reflection.
+// Any other value falls back to reflection, same as ReflectSizer.
+type ByteSizer struct{}
+
+// Size returns len(v) for []byte and string, falling back to reflection
+// for any other type
+func (ByteSizer) Size(v interface{}) uint64 {
+	switch val := v.(type) {
+	case []byte:
+		return uint64(len(val))
+	case string:
+		return uint64(len(val))
+	default:
+		return sizeof(v)
+	}
+}
+
+// TypedSizer is a Sizer specialized for a single value type T. If T's
+// layout is fully fixed-size (no strings, slices, maps, pointers,
+// interfaces or similarly variable-size fields anywhere in it),
+// NewTypedSizer precomputes its size once so Size avoids reflection
+// entirely on every call; otherwise it falls back to the same reflection
+// walk as ReflectSizer.
+type TypedSizer[T any] struct {
+	fixedSize uint64
+	fixed     bool
+}
+
+// NewTypedSizer builds a Sizer for T, memoizing its size up front when
+// T's layout is fully fixed-size
+func NewTypedSizer[T any]() *TypedSizer[T] {
+	typ := reflect.TypeOf((*T)(nil)).Elem()
+	if isFullyFixedType(typ) {
+		return &TypedSizer[T]{fixedSize: uint64(typ.Size()), fixed: true}
+	}
+	return &TypedSizer[T]{}
+}
+
+// Size returns the precomputed fixed size for T, or falls back to
+// reflection if T's layout isn't fully fixed-size
+func (s *TypedSizer[T]) Size(v interface{}) uint64 {
+	if s.fixed {
+		return s.fixedSize
+	}
+	return sizeofInternal(reflect.ValueOf(v), false, 0)
+}
+
+// isFullyFixedType reports whether typ's in-memory layout, including all
+// of its fields recursively, is free of any variable-size data (strings,
+// slices, maps, pointers, interfaces, channels, funcs), making its
+// reflect.Type.Size() alone an exact size
+func isFullyFixedType(typ reflect.Type) bool {
+	switch typ.Kind() {
+	case reflect.String, reflect.Slice, reflect.Map, reflect.Ptr,
+		reflect.Interface, reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		return false
+	case reflect.Array:
+		return isFullyFixedType(typ.Elem())
+	case reflect.Struct:
+		for i := 0; i < typ.NumField(); i++ {
+			if !isFullyFixedType(typ.Field(i).Type) {
+				return false
+			}
+		}
+		return true
+	default:
+		return true
+	}
+}
@@ -0,0 +1,54 @@
+package accesscache
+
+import "testing"
+
+type fixedPoint struct {
+	X int64
+	Y int64
+}
+
+type sizeableValue struct {
+	reported uint64
+}
+
+func (s sizeableValue) CacheSize() uint64 {
+	return s.reported
+}
+
+func TestByteSizerFastPath(t *testing.T) {
+	var s ByteSizer
+	assert(t, uint64(5), s.Size([]byte("hello")))
+	assert(t, uint64(3), s.Size("abc"))
+}
+
+func TestByteSizerFallsBackToReflection(t *testing.T) {
+	var s ByteSizer
+	assert(t, ReflectSizer{}.Size(1024), s.Size(1024))
+}
+
+func TestTypedSizerFixedType(t *testing.T) {
+	s := NewTypedSizer[fixedPoint]()
+	assert(t, true, s.fixed)
+	p := fixedPoint{X: 1, Y: 2}
+	assert(t, s.fixedSize, s.Size(p))
+	// Size doesn't depend on the value once the type is fixed-size
+	assert(t, s.Size(p), s.Size(fixedPoint{X: 100, Y: 200}))
+}
+
+func TestTypedSizerVariableType(t *testing.T) {
+	s := NewTypedSizer[string]()
+	assert(t, false, s.fixed)
+	assert(t, ReflectSizer{}.Size("hello"), s.Size("hello"))
+}
+
+func TestSizeableOverridesConfiguredSizer(t *testing.T) {
+	m := NewAccessCacheWithSizer(1024, ByteSizer{})
+	m.Set("a", sizeableValue{reported: 42})
+	assert(t, uint64(42), m.GetItemSizes()["a"])
+}
+
+func TestNewAccessCacheWithSizer(t *testing.T) {
+	m := NewAccessCacheWithSizer(1024, ByteSizer{})
+	m.Set("a", []byte("hello"))
+	assert(t, uint64(5), m.GetItemSizes()["a"])
+}
@@ -0,0 +1,157 @@
+package accesscache
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"time"
+)
+
+// snapshotVersion is bumped whenever the on-disk format of snapshot
+// changes, so Load can refuse a stream it doesn't know how to read
+// instead of misinterpreting it.
+const snapshotVersion = 1
+
+// persistedEntry is a single cache entry as written by Save. Value is
+// stored as its own self-contained gob stream (rather than as a field of
+// snapshot) so a value whose concrete type fails to encode can be
+// dropped without corrupting the entries around it.
+type persistedEntry struct {
+	Key       string
+	Value     []byte
+	Size      uint64
+	ExpiresAt time.Time
+}
+
+// snapshot is the top level structure written to a Save stream
+type snapshot struct {
+	Version int
+	Entries []persistedEntry
+}
+
+// RegisterType registers a concrete type that may be stored as a cache
+// value with encoding/gob, so Save and Load can round-trip it. Call it
+// once at startup for every concrete type you pass to Set that gob does
+// not already know; gob's built-in types (e.g. int, string, []byte) need
+// no registration. Values of an unregistered type are dropped from the
+// snapshot rather than failing Save outright.
+func RegisterType(value interface{}) {
+	gob.Register(value)
+}
+
+// Save writes a snapshot of the cache to w, including every entry's
+// size and TTL and, for eviction policies that track one (e.g. LRU), its
+// recency order, so a process restored from this snapshot resumes with
+// the same eviction state it left off with. Values of a concrete type
+// that is neither one of gob's built-in types nor passed to RegisterType
+// can't be gob-encoded and are silently dropped from the snapshot; Save
+// itself does not fail because of them.
+func (c *AccessCache) Save(w io.Writer) error {
+	c.mux.RLock()
+	defer c.mux.RUnlock()
+
+	keys := c.keysInOrderLocked()
+	if keys == nil {
+		keys = make([]string, 0, len(c.cache))
+		for key := range c.cache {
+			keys = append(keys, key)
+		}
+	}
+
+	snap := snapshot{Version: snapshotVersion, Entries: make([]persistedEntry, 0, len(keys))}
+	for _, key := range keys {
+		value := c.cache[key]
+
+		var blob bytes.Buffer
+		if err := gob.NewEncoder(&blob).Encode(&value); err != nil {
+			if c.verbose {
+				log.Println("Save: dropping", key, "- type not registered with RegisterType:", err)
+			}
+			continue
+		}
+
+		snap.Entries = append(snap.Entries, persistedEntry{
+			Key:       key,
+			Value:     blob.Bytes(),
+			Size:      c.sizes[key],
+			ExpiresAt: c.expiresAt[key],
+		})
+	}
+
+	return gob.NewEncoder(w).Encode(&snap)
+}
+
+// Load restores entries from a snapshot written by Save. Entries already
+// in the cache are overwritten by a restored entry with the same key.
+// An entry whose TTL had already passed by the time Load runs, or whose
+// value can't be decoded (e.g. its type isn't registered in the loading
+// process), is skipped rather than failing the whole load.
+func (c *AccessCache) Load(r io.Reader) error {
+	var snap snapshot
+	if err := gob.NewDecoder(r).Decode(&snap); err != nil {
+		return err
+	}
+	if snap.Version != snapshotVersion {
+		return fmt.Errorf("accesscache: unsupported snapshot version %d", snap.Version)
+	}
+
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	now := time.Now()
+	for _, entry := range snap.Entries {
+		if !entry.ExpiresAt.IsZero() && !now.Before(entry.ExpiresAt) {
+			continue
+		}
+
+		var value interface{}
+		if err := gob.NewDecoder(bytes.NewReader(entry.Value)).Decode(&value); err != nil {
+			if c.verbose {
+				log.Println("Load: dropping", entry.Key, "- decode failed:", err)
+			}
+			continue
+		}
+
+		oldSize, existed := c.sizes[entry.Key]
+		c.policy.Add(entry.Key, entry.Size)
+		c.cache[entry.Key] = value
+		c.sizes[entry.Key] = entry.Size
+		if !entry.ExpiresAt.IsZero() {
+			c.expiresAt[entry.Key] = entry.ExpiresAt
+		} else {
+			delete(c.expiresAt, entry.Key)
+		}
+		if existed {
+			c.curSize = c.curSize - oldSize + entry.Size
+		} else {
+			c.curSize += entry.Size
+		}
+	}
+
+	c.clearOutdatedItems()
+	return nil
+}
+
+// SaveFile writes a snapshot of the cache to the file at path, creating
+// it if needed and truncating any existing contents
+func (c *AccessCache) SaveFile(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return c.Save(f)
+}
+
+// LoadFile restores entries from the snapshot file at path
+func (c *AccessCache) LoadFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return c.Load(f)
+}
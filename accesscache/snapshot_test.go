@@ -0,0 +1,75 @@
+package accesscache
+
+import (
+	"bytes"
+	"encoding/gob"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSaveLoadRoundTrip(t *testing.T) {
+	m := NewAccessCache(1024)
+	m.Set("a", 1)
+	m.Set("b", 2)
+	m.Get("a")
+
+	var buf bytes.Buffer
+	err := m.Save(&buf)
+	assert(t, nil, err)
+
+	restored := NewAccessCache(1024)
+	err = restored.Load(&buf)
+	assert(t, nil, err)
+
+	assert(t, 2, restored.Count())
+	value, ok := restored.Get("a")
+	assert(t, true, ok)
+	assert(t, value, 1)
+	value, ok = restored.Get("b")
+	assert(t, true, ok)
+	assert(t, value, 2)
+}
+
+func TestSaveLoadSkipsExpiredEntries(t *testing.T) {
+	m := NewAccessCache(1024)
+	m.SetWithTTL("a", 1, time.Millisecond)
+	time.Sleep(5 * time.Millisecond)
+
+	var buf bytes.Buffer
+	err := m.Save(&buf)
+	assert(t, nil, err)
+
+	restored := NewAccessCache(1024)
+	err = restored.Load(&buf)
+	assert(t, nil, err)
+	assert(t, 0, restored.Count())
+}
+
+func TestSaveLoadFileRoundTrip(t *testing.T) {
+	m := NewAccessCache(1024)
+	m.Set("a", 1)
+
+	path := filepath.Join(t.TempDir(), "snapshot.gob")
+	err := m.SaveFile(path)
+	assert(t, nil, err)
+
+	restored := NewAccessCache(1024)
+	err = restored.LoadFile(path)
+	assert(t, nil, err)
+	assert(t, 1, restored.Count())
+}
+
+func TestLoadRejectsUnknownVersion(t *testing.T) {
+	var badBuf bytes.Buffer
+	badSnap := snapshot{Version: snapshotVersion + 1}
+	if err := gob.NewEncoder(&badBuf).Encode(&badSnap); err != nil {
+		t.Fatal(err)
+	}
+
+	restored := NewAccessCache(1024)
+	err := restored.Load(&badBuf)
+	if err == nil {
+		t.Errorf("expected an error for an unsupported snapshot version")
+	}
+}